@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// newCompletionCmd exposes cobra's built-in shell completion generator.
+// It's hidden from `galaplate help` since most users discover it via
+// `galaplate completion --help` or their shell's own setup docs.
+func newCompletionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion [bash|zsh|fish|powershell]",
+		Short: "Generate shell completion scripts",
+		Long: `Generate a shell completion script for galaplate.
+
+To load completions:
+
+Bash:
+  $ source <(galaplate completion bash)
+
+Zsh:
+  $ galaplate completion zsh > "${fpath[1]}/_galaplate"
+
+Fish:
+  $ galaplate completion fish > ~/.config/fish/completions/galaplate.fish
+
+PowerShell:
+  PS> galaplate completion powershell | Out-String | Invoke-Expression
+`,
+		Hidden:    true,
+		ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch args[0] {
+			case "bash":
+				return cmd.Root().GenBashCompletion(os.Stdout)
+			case "zsh":
+				return cmd.Root().GenZshCompletion(os.Stdout)
+			case "fish":
+				return cmd.Root().GenFishCompletion(os.Stdout, true)
+			case "powershell":
+				return cmd.Root().GenPowerShellCompletionWithDesc(os.Stdout)
+			}
+			return nil
+		},
+	}
+}
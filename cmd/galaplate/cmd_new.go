@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func newNewCmd() *cobra.Command {
+	opts := &ProjectOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "new [project-name]",
+		Short: "Create a new Galaplate project",
+		Long:  "Create a new Galaplate project. Omit the project name to launch the interactive wizard.",
+		Args:  cobra.MaximumNArgs(1),
+		Example: `  galaplate new                                           # Launch the interactive wizard
+  galaplate new my-api                                    # Simple API project
+  galaplate new my-app --template=full --db=mysql        # Full-stack with MySQL
+  galaplate new my-api --source=gitea:git.example.com/acme/tmpl --ref=v1.2.3
+  galaplate new my-api --local=./my-template             # From a local template dir
+  galaplate new microservice --template=micro --no-git   # Microservice without git
+  galaplate new my-svc --profile=work                    # Regenerate with saved answers`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				opts.Name = args[0]
+			}
+			if opts.Module == "" {
+				opts.Module = opts.Name
+			}
+			return runNewCmd(cmd, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.Template, "template", "api", "Template to use (api, full, micro)")
+	flags.StringVar(&opts.DbType, "db", "postgres", "Database type (postgres, mysql)")
+	flags.StringVar(&opts.Module, "module", "", "Custom Go module name [default: project-name]")
+	flags.BoolVar(&opts.NoGit, "no-git", false, "Skip git initialization")
+	flags.BoolVar(&opts.Force, "force", false, "Overwrite existing directory")
+	flags.StringVar(&opts.Source.Raw, "source", "", "Template source: github:owner/repo, gitea:host/owner/repo, gitlab:owner/repo, a git URL, or owner/repo [default: github:galaplate/galaplate]")
+	flags.StringVar(&opts.Source.Ref, "ref", "", "Branch, tag, or commit to pin the template to (git URL sources: branch or tag only)")
+	flags.StringVar(&opts.Source.Local, "local", "", "Use a template from a local directory instead of downloading")
+	flags.BoolVar(&opts.Source.NoCache, "no-cache", false, "Skip the ~/.galaplate/cache template cache")
+	flags.BoolVar(&opts.Interactive, "interactive", false, "Walk through an interactive wizard instead of flags")
+	flags.StringVar(&opts.Profile, "profile", "", "Start from a saved profile under ~/.config/galaplate/profiles")
+	flags.StringVar(&opts.SaveProfile, "save-profile", "", "Save the wizard's answers as a reusable profile")
+	flags.StringVar(&opts.Features, "features", "", "Comma-separated features to enable from the template manifest")
+
+	return cmd
+}
+
+func runNewCmd(cmd *cobra.Command, opts *ProjectOptions) error {
+	if opts.Profile != "" {
+		profile, err := LoadProfile(opts.Profile)
+		if err != nil {
+			return err
+		}
+		applyProfile(cmd, opts, profile)
+	}
+
+	if opts.Name == "" || opts.Interactive {
+		if err := runWizard(opts); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("🚀 Creating new Galaplate project: %s\n", opts.Name)
+	fmt.Printf("📦 Template: %s\n", opts.Template)
+	fmt.Printf("🗄️  Database: %s\n", opts.DbType)
+	fmt.Printf("📝 Module: %s\n", opts.Module)
+	fmt.Println()
+
+	if _, err := os.Stat(opts.Name); err == nil && !opts.Force {
+		return fmt.Errorf("directory '%s' already exists. Use --force to overwrite", opts.Name)
+	}
+
+	return createProjectFromSource(opts)
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newProfilesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "profiles",
+		Short: "List saved `galaplate new` profiles",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			names, err := ListProfiles()
+			if err != nil {
+				return fmt.Errorf("failed to list profiles: %v", err)
+			}
+
+			if len(names) == 0 {
+				fmt.Println("No saved profiles yet. Create one with:")
+				fmt.Println("  galaplate new --interactive --save-profile=<name>")
+				return nil
+			}
+
+			fmt.Println("📋 Saved profiles:")
+			for _, name := range names {
+				fmt.Printf("  - %s\n", name)
+			}
+			fmt.Println()
+			fmt.Println("Usage: galaplate new my-project --profile=<name>")
+			return nil
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newTemplatesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "templates",
+		Short: "List available project templates",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Println("📋 Available Templates:")
+			fmt.Println()
+			fmt.Println("  🔹 api     - REST API only (default)")
+			fmt.Println("           Features: HTTP server, Database, Auth, Jobs, Console")
+			fmt.Println("           Best for: Backend APIs, microservices")
+			fmt.Println()
+			fmt.Println("  🔹 full    - Full-stack application")
+			fmt.Println("           Features: API + Frontend templates + Static assets")
+			fmt.Println("           Best for: Web applications with UI")
+			fmt.Println()
+			fmt.Println("  🔹 micro   - Microservice template")
+			fmt.Println("           Features: Minimal API, Service discovery, Health checks")
+			fmt.Println("           Best for: Distributed systems, minimal services")
+			fmt.Println()
+			fmt.Println("Usage: galaplate new my-project --template=api")
+			return nil
+		},
+	}
+}
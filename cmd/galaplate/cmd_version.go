@@ -0,0 +1,21 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newVersionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Show CLI version information",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Printf("Galaplate CLI v%s\n", version)
+			fmt.Printf("Built: %s\n", date)
+			fmt.Printf("Commit: %s\n", commit)
+			fmt.Println("galaplate core: v0.0.0-local")
+			return nil
+		},
+	}
+}
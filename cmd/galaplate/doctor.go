@@ -0,0 +1,320 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// checkStatus is the outcome of a single doctor check.
+type checkStatus int
+
+const (
+	checkOK checkStatus = iota
+	checkWarn
+	checkFail
+)
+
+type checkResult struct {
+	Name   string
+	Status checkStatus
+	Detail string
+}
+
+func (r checkResult) String() string {
+	icon := "✅"
+	switch r.Status {
+	case checkWarn:
+		icon = "⚠️ "
+	case checkFail:
+		icon = "❌"
+	}
+	if r.Detail == "" {
+		return fmt.Sprintf("%s %s", icon, r.Name)
+	}
+	return fmt.Sprintf("%s %s: %s", icon, r.Name, r.Detail)
+}
+
+// runDoctor validates that projectDir actually builds and that the
+// toolchain prerequisites it needs are on PATH. It is used both right
+// after project generation and via the standalone `galaplate doctor`
+// command.
+func runDoctor(projectDir string, dbType string, interactive bool) ([]checkResult, error) {
+	var results []checkResult
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(projectDir); err != nil {
+		return nil, err
+	}
+
+	results = append(results, checkGoVersion())
+	results = append(results, checkGoBuild())
+	results = append(results, checkGoVet())
+	results = append(results, checkOnPath("git"))
+	results = append(results, checkOnPath("docker"))
+	results = append(results, checkDBClient(dbType))
+	results = append(results, checkEnvExample(interactive)...)
+
+	return results, nil
+}
+
+func checkGoVersion() checkResult {
+	data, err := os.ReadFile("go.mod")
+	if err != nil {
+		return checkResult{Name: "go.mod", Status: checkWarn, Detail: "not found, skipping Go version check"}
+	}
+
+	re := regexp.MustCompile(`(?m)^go\s+(\S+)`)
+	match := re.FindStringSubmatch(string(data))
+	if match == nil {
+		return checkResult{Name: "go.mod", Status: checkWarn, Detail: "no \"go\" directive found"}
+	}
+	required := match[1]
+
+	out, err := exec.Command("go", "version").Output()
+	if err != nil {
+		return checkResult{Name: "Go toolchain", Status: checkFail, Detail: "go is not installed"}
+	}
+
+	installed := strings.TrimSpace(strings.TrimPrefix(strings.Fields(string(out))[2], "go"))
+	if compareVersions(installed, required) < 0 {
+		return checkResult{
+			Name:   "Go toolchain",
+			Status: checkFail,
+			Detail: fmt.Sprintf("go.mod requires go %s, found go %s", required, installed),
+		}
+	}
+	return checkResult{Name: "Go toolchain", Status: checkOK, Detail: fmt.Sprintf("go %s (requires %s)", installed, required)}
+}
+
+// compareVersions compares two dotted numeric version strings, returning
+// -1, 0, or 1 the way strings.Compare does. Each component is read by its
+// leading digits (a component with none counts as 0), so a suffix is
+// ignored and "1.21rc1" compares equal to "1.21".
+func compareVersions(a, b string) int {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var an, bn int
+		if i < len(as) {
+			fmt.Sscanf(as[i], "%d", &an)
+		}
+		if i < len(bs) {
+			fmt.Sscanf(bs[i], "%d", &bn)
+		}
+		if an != bn {
+			if an < bn {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func checkGoBuild() checkResult {
+	if _, err := os.Stat("go.mod"); err != nil {
+		return checkResult{Name: "go build ./...", Status: checkWarn, Detail: "no go.mod, skipping"}
+	}
+	out, err := exec.Command("go", "build", "./...").CombinedOutput()
+	if err != nil {
+		return checkResult{Name: "go build ./...", Status: checkFail, Detail: strings.TrimSpace(string(out))}
+	}
+	return checkResult{Name: "go build ./...", Status: checkOK}
+}
+
+func checkGoVet() checkResult {
+	if _, err := os.Stat("go.mod"); err != nil {
+		return checkResult{Name: "go vet ./...", Status: checkWarn, Detail: "no go.mod, skipping"}
+	}
+	out, err := exec.Command("go", "vet", "./...").CombinedOutput()
+	if err != nil {
+		return checkResult{Name: "go vet ./...", Status: checkFail, Detail: strings.TrimSpace(string(out))}
+	}
+	return checkResult{Name: "go vet ./...", Status: checkOK}
+}
+
+func checkOnPath(bin string) checkResult {
+	if _, err := exec.LookPath(bin); err != nil {
+		return checkResult{Name: bin, Status: checkWarn, Detail: "not found on PATH"}
+	}
+	return checkResult{Name: bin, Status: checkOK}
+}
+
+func checkDBClient(dbType string) checkResult {
+	client := map[string]string{
+		"postgres": "psql",
+		"mysql":    "mysql",
+	}[dbType]
+	if client == "" {
+		return checkResult{Name: "database client", Status: checkWarn, Detail: fmt.Sprintf("unknown db type %q", dbType)}
+	}
+	return checkOnPath(client)
+}
+
+// checkEnvExample verifies every variable declared in .env.example has a
+// value in .env, prompting to fill missing ones in interactive mode.
+func checkEnvExample(interactive bool) []checkResult {
+	example, err := os.ReadFile(".env.example")
+	if err != nil {
+		return []checkResult{{Name: ".env.example", Status: checkWarn, Detail: "not found, skipping"}}
+	}
+
+	exampleVars := parseEnvKeys(string(example))
+	if len(exampleVars) == 0 {
+		return nil
+	}
+
+	envPath := ".env"
+	envContent, _ := os.ReadFile(envPath)
+	envVars := parseEnvFile(string(envContent))
+
+	var missing []string
+	for _, key := range exampleVars {
+		if v, ok := envVars[key]; !ok || v == "" {
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) == 0 {
+		return []checkResult{{Name: ".env", Status: checkOK, Detail: "all variables set"}}
+	}
+
+	if interactive {
+		reader := bufio.NewReader(os.Stdin)
+		for _, key := range missing {
+			value := promptString(reader, fmt.Sprintf("Value for %s", key), "")
+			envVars[key] = value
+		}
+		if err := writeEnvFile(envPath, exampleVars, envVars); err != nil {
+			return []checkResult{{Name: ".env", Status: checkFail, Detail: err.Error()}}
+		}
+		return []checkResult{{Name: ".env", Status: checkOK, Detail: "filled in missing variables"}}
+	}
+
+	return []checkResult{{
+		Name:   ".env",
+		Status: checkWarn,
+		Detail: fmt.Sprintf("missing values for: %s", strings.Join(missing, ", ")),
+	}}
+}
+
+func parseEnvKeys(content string) []string {
+	var keys []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if key, _, ok := strings.Cut(line, "="); ok {
+			keys = append(keys, strings.TrimSpace(key))
+		}
+	}
+	return keys
+}
+
+func parseEnvFile(content string) map[string]string {
+	vars := map[string]string{}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, "="); ok {
+			vars[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return vars
+}
+
+// writeEnvFile writes vars to path, ordered by order (.env.example's keys)
+// first. Any key present in vars but not in order — e.g. a variable the
+// project's real .env already had that .env.example never declared — is
+// preserved and appended rather than dropped.
+func writeEnvFile(path string, order []string, vars map[string]string) error {
+	written := make(map[string]bool, len(order))
+	var b strings.Builder
+	for _, key := range order {
+		fmt.Fprintf(&b, "%s=%s\n", key, vars[key])
+		written[key] = true
+	}
+
+	extra := make([]string, 0, len(vars)-len(written))
+	for key := range vars {
+		if !written[key] {
+			extra = append(extra, key)
+		}
+	}
+	sort.Strings(extra)
+	for _, key := range extra {
+		fmt.Fprintf(&b, "%s=%s\n", key, vars[key])
+	}
+
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
+
+func newDoctorCmd() *cobra.Command {
+	var dbType string
+	var interactive bool
+
+	cmd := &cobra.Command{
+		Use:   "doctor [path]",
+		Short: "Validate a generated project's toolchain and build",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectDir := "."
+			if len(args) == 1 {
+				projectDir = args[0]
+			}
+			return runDoctorCmd(projectDir, dbType, interactive)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&dbType, "db", "postgres", "Database client to check for (postgres, mysql)")
+	flags.BoolVar(&interactive, "interactive", false, "Prompt to fill in missing .env variables")
+
+	return cmd
+}
+
+func runDoctorCmd(projectDir, dbType string, interactive bool) error {
+	absDir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🩺 Running doctor checks in %s...\n\n", absDir)
+
+	results, err := runDoctor(projectDir, dbType, interactive)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range results {
+		fmt.Println(r.String())
+		if r.Status == checkFail {
+			failed++
+		}
+	}
+
+	fmt.Println()
+	if failed > 0 {
+		fmt.Printf("❌ %d check(s) failed\n", failed)
+		os.Exit(1)
+	}
+	fmt.Println("✅ All checks passed")
+	return nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.21", "1.21", 0},
+		{"1.21.0", "1.21", 0},
+		{"1.9", "1.10", -1},
+		{"1.10", "1.9", 1},
+		{"1.21.3", "1.21.10", -1},
+		{"2.0", "1.21", 1},
+		{"1.21rc1", "1.21", 0},
+	}
+
+	for _, tc := range cases {
+		if got := compareVersions(tc.a, tc.b); got != tc.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestWriteEnvFilePreservesUnknownKeys(t *testing.T) {
+	dir := t.TempDir()
+	path := dir + "/.env"
+
+	order := []string{"APP_NAME", "DB_HOST"}
+	vars := map[string]string{
+		"APP_NAME":    "galaplate",
+		"DB_HOST":     "localhost",
+		"CUSTOM_FLAG": "enabled",
+	}
+
+	if err := writeEnvFile(path, order, vars); err != nil {
+		t.Fatalf("writeEnvFile: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %s: %v", path, err)
+	}
+
+	got := parseEnvFile(string(data))
+	for key, want := range vars {
+		if got[key] != want {
+			t.Errorf("wrote %s=%q, want %q", key, got[key], want)
+		}
+	}
+}
@@ -1,17 +1,16 @@
 package main
 
 import (
-	"archive/tar"
-	"compress/gzip"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	"slices"
 	"strings"
+
+	"github.com/spf13/cobra"
 )
 
 var (
@@ -35,121 +34,93 @@ type TemplateConfig struct {
 }
 
 type ProjectOptions struct {
-	Name     string
-	Template string
-	DbType   string
-	Module   string
-	NoGit    bool
-	Force    bool
+	Name        string
+	Template    string
+	DbType      string
+	Module      string
+	NoGit       bool
+	Force       bool
+	Source      SourceOptions
+	Interactive bool
+	Profile     string
+	SaveProfile string
+	Features    string
 }
 
-func main() {
-	if len(os.Args) < 2 {
-		showHelp()
-		return
+// applyProfile seeds opts with a previously saved profile's answers. It
+// runs after flags are parsed, so any flag the caller passed explicitly on
+// the command line wins over the profile's saved value.
+func applyProfile(cmd *cobra.Command, opts *ProjectOptions, p *Profile) {
+	flags := cmd.Flags()
+	if !flags.Changed("template") {
+		opts.Template = p.Template
 	}
-
-	cmd := os.Args[1]
-	args := os.Args[2:]
-
-	switch cmd {
-	case "new":
-		if err := handleNewProject(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "version":
-		showVersion()
-	case "templates":
-		if err := listTemplates(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "help", "--help", "-h":
-		showHelp()
-	default:
-		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", cmd)
-		showHelp()
-		os.Exit(1)
+	if !flags.Changed("db") {
+		opts.DbType = p.DbType
 	}
-}
-
-func handleNewProject(args []string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("project name is required")
+	if !flags.Changed("module") {
+		opts.Module = p.Module
 	}
-
-	opts := parseProjectArgs(args)
-
-	fmt.Printf("🚀 Creating new Galaplate project: %s\n", opts.Name)
-	fmt.Printf("📦 Template: %s\n", opts.Template)
-	fmt.Printf("🗄️  Database: %s\n", opts.DbType)
-	fmt.Printf("📝 Module: %s\n", opts.Module)
-	fmt.Println()
-
-	// Check if directory exists
-	if _, err := os.Stat(opts.Name); err == nil && !opts.Force {
-		return fmt.Errorf("directory '%s' already exists. Use --force to overwrite", opts.Name)
+	if !flags.Changed("no-git") {
+		opts.NoGit = p.NoGit
+	}
+	if !flags.Changed("features") {
+		opts.Features = strings.Join(p.Features, ",")
 	}
-
-	// Download from GitHub and create project
-	return createProjectFromGitHub(opts)
 }
 
-func parseProjectArgs(args []string) *ProjectOptions {
-	opts := &ProjectOptions{
-		Name:     args[0],
-		Template: "api",
-		DbType:   "postgres",
-		Module:   args[0],
-		NoGit:    false,
-		Force:    false,
-	}
+func createProjectFromSource(opts *ProjectOptions) error {
+	fmt.Println("📥 Resolving Galaplate template...")
 
-	for _, arg := range args[1:] {
-		if strings.HasPrefix(arg, "--template=") {
-			opts.Template = strings.TrimPrefix(arg, "--template=")
-		} else if strings.HasPrefix(arg, "--db=") {
-			opts.DbType = strings.TrimPrefix(arg, "--db=")
-		} else if strings.HasPrefix(arg, "--module=") {
-			opts.Module = strings.TrimPrefix(arg, "--module=")
-		} else if arg == "--no-git" {
-			opts.NoGit = true
-		} else if arg == "--force" {
-			opts.Force = true
-		}
+	src, err := ResolveTemplateSource(opts.Source)
+	if err != nil {
+		return fmt.Errorf("failed to resolve template source: %v", err)
 	}
+	defer src.Cleanup()
 
-	return opts
-}
-
-func createProjectFromGitHub(opts *ProjectOptions) error {
-	// Download the latest release from GitHub
-	fmt.Println("📥 Downloading Galaplate template from GitHub...")
-
-	tempDir, err := downloadGitHubRepo("galaplate", "galaplate", "main")
+	tempDir, err := src.Fetch()
 	if err != nil {
 		return fmt.Errorf("failed to download template: %v", err)
 	}
-	defer os.RemoveAll(tempDir)
 
 	fmt.Printf("📁 Template downloaded to: %s\n", tempDir)
 
+	cfg, err := loadManifest(tempDir)
+	if err != nil {
+		return fmt.Errorf("failed to load template manifest: %v", err)
+	}
+	enabled := featuresEnabled(opts.Features)
+	if missing := requiredFeatures(cfg, enabled); len(missing) > 0 {
+		fmt.Printf("ℹ️  Template declares features not requested via --features: %s\n", strings.Join(missing, ", "))
+	}
+
 	// Create project directory
 	if err := os.MkdirAll(opts.Name, 0755); err != nil {
 		return fmt.Errorf("failed to create project directory: %v", err)
 	}
 
 	// Copy files from downloaded template
-	if err := copyProjectFiles(tempDir, opts.Name, opts); err != nil {
+	if err := copyProjectFiles(tempDir, opts.Name, opts, cfg, enabled); err != nil {
 		return fmt.Errorf("failed to copy project files: %v", err)
 	}
 
 	// Setup project
-	if err := setupProject(opts); err != nil {
+	if err := setupProject(opts, cfg); err != nil {
 		return fmt.Errorf("failed to setup project: %v", err)
 	}
 
+	// Validate the generated project actually builds before declaring victory.
+	fmt.Println()
+	results, err := runDoctor(opts.Name, opts.DbType, opts.Interactive)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: doctor checks could not run: %v\n", err)
+	} else {
+		fmt.Println("🩺 Doctor checks:")
+		for _, r := range results {
+			fmt.Println(r.String())
+		}
+	}
+
 	fmt.Printf("\n✅ Project '%s' created successfully!\n\n", opts.Name)
 	fmt.Println("🎯 Next steps:")
 	fmt.Printf("   cd %s\n", opts.Name)
@@ -168,95 +139,7 @@ func createProjectFromGitHub(opts *ProjectOptions) error {
 	return nil
 }
 
-func downloadGitHubRepo(owner, repo, branch string) (string, error) {
-	// Create temporary directory
-	tempDir, err := os.MkdirTemp("", "galaplate-")
-	if err != nil {
-		return "", err
-	}
-
-	// Download the repository archive
-	url := fmt.Sprintf("https://github.com/%s/%s/archive/refs/heads/%s.tar.gz", owner, repo, branch)
-	resp, err := http.Get(url)
-	if err != nil {
-		os.RemoveAll(tempDir)
-		return "", err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		os.RemoveAll(tempDir)
-		return "", fmt.Errorf("failed to download: HTTP %d", resp.StatusCode)
-	}
-
-	// Extract the tar.gz file
-	gzr, err := gzip.NewReader(resp.Body)
-	if err != nil {
-		os.RemoveAll(tempDir)
-		return "", err
-	}
-	defer gzr.Close()
-
-	tr := tar.NewReader(gzr)
-
-	for {
-		header, err := tr.Next()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			os.RemoveAll(tempDir)
-			return "", err
-		}
-
-		// Skip the top-level directory (e.g., "galaplate-main/")
-		parts := strings.Split(header.Name, "/")
-		if len(parts) <= 1 {
-			continue
-		}
-
-		// Reconstruct path without the top-level directory
-		path := filepath.Join(tempDir, filepath.Join(parts[1:]...))
-
-		switch header.Typeflag {
-		case tar.TypeDir:
-			if err := os.MkdirAll(path, os.FileMode(header.Mode)); err != nil {
-				os.RemoveAll(tempDir)
-				return "", err
-			}
-		case tar.TypeReg:
-			// Create directory if it doesn't exist
-			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
-				os.RemoveAll(tempDir)
-				return "", err
-			}
-
-			// Create file
-			file, err := os.Create(path)
-			if err != nil {
-				os.RemoveAll(tempDir)
-				return "", err
-			}
-
-			if _, err := io.Copy(file, tr); err != nil {
-				file.Close()
-				os.RemoveAll(tempDir)
-				return "", err
-			}
-			file.Close()
-
-			// Set file permissions
-			if err := os.Chmod(path, os.FileMode(header.Mode)); err != nil {
-				os.RemoveAll(tempDir)
-				return "", err
-			}
-		}
-	}
-
-	return tempDir, nil
-}
-
-func copyProjectFiles(srcRoot, destDir string, opts *ProjectOptions) error {
+func copyProjectFiles(srcRoot, destDir string, opts *ProjectOptions, cfg *TemplateConfig, enabled map[string]bool) error {
 	excludeDirs := []string{
 		"cli", ".git", "node_modules", "tmp", "storage",
 		"cmd", "test-api", // Exclude any test directories
@@ -269,6 +152,7 @@ func copyProjectFiles(srcRoot, destDir string, opts *ProjectOptions) error {
 		"galaplate", // Exclude compiled CLI binary
 		"AGENTS.md", "CORE_EXTRACTION_PLAN.md", "IMPLEMENTATION_ROADMAP.md",
 		"PHASE1_PROGRESS_REPORT.md", "WORKFLOW_EXAMPLE.md", // Exclude development docs
+		manifestJSONName, manifestYAMLName, // The manifest itself isn't part of the generated project
 	}
 
 	return filepath.Walk(srcRoot, func(path string, info os.FileInfo, err error) error {
@@ -303,6 +187,21 @@ func copyProjectFiles(srcRoot, destDir string, opts *ProjectOptions) error {
 			return nil
 		}
 
+		// Honor the manifest's Structure.Include/Exclude globs, if any. A
+		// directory that only fails Include is still walked (but not created),
+		// since "internal/**/*.go" matches files below "internal" but not
+		// "internal" itself; an excluded directory is skipped entirely.
+		if !manifestAllows(cfg, relPath) {
+			if info.IsDir() {
+				if slices.ContainsFunc(cfg.Structure.Exclude, func(p string) bool {
+					return matchManifestPattern(p, relPath)
+				}) {
+					return filepath.SkipDir
+				}
+			}
+			return nil
+		}
+
 		destPath := filepath.Join(destDir, relPath)
 
 		if info.IsDir() {
@@ -310,11 +209,11 @@ func copyProjectFiles(srcRoot, destDir string, opts *ProjectOptions) error {
 		}
 
 		// Copy file
-		return copyFile(path, destPath, opts)
+		return copyFile(path, destPath, opts, cfg, enabled)
 	})
 }
 
-func copyFile(src, dest string, opts *ProjectOptions) error {
+func copyFile(src, dest string, opts *ProjectOptions, cfg *TemplateConfig, enabled map[string]bool) error {
 	srcFile, err := os.Open(src)
 	if err != nil {
 		return err
@@ -345,14 +244,13 @@ func copyFile(src, dest string, opts *ProjectOptions) error {
 		}
 
 		// Apply template replacements
-		contentStr := string(content)
-		contentStr = strings.ReplaceAll(contentStr, "{{PROJECT_NAME}}", opts.Name)
-		contentStr = strings.ReplaceAll(contentStr, "{{MODULE_NAME}}", opts.Module)
-		contentStr = strings.ReplaceAll(contentStr, "{{DB_TYPE}}", opts.DbType)
-		contentStr = strings.ReplaceAll(contentStr, "{{GALAPLATE_CORE_VERSION}}", "v0.0.0")
+		rendered, err := renderTemplate(string(content), opts, cfg, enabled)
+		if err != nil {
+			return fmt.Errorf("rendering %s: %v", src, err)
+		}
 
 		// Write processed content
-		return os.WriteFile(dest, []byte(contentStr), 0644)
+		return os.WriteFile(dest, []byte(rendered), 0644)
 	}
 
 	// Special handling for go.mod and Go files
@@ -392,7 +290,7 @@ func replaceModuleReferences(content, newModule string) string {
 	return content
 }
 
-func setupProject(opts *ProjectOptions) error {
+func setupProject(opts *ProjectOptions, cfg *TemplateConfig) error {
 	projectDir := opts.Name
 
 	fmt.Printf("🔧 Setting up project in %s...\n", projectDir)
@@ -420,6 +318,15 @@ func setupProject(opts *ProjectOptions) error {
 		}
 	}
 
+	// A manifest's setup_steps fully replace the default "go mod tidy" flow.
+	if cfg != nil && len(cfg.SetupSteps) > 0 {
+		if err := runSetupSteps(cfg.SetupSteps); err != nil {
+			return err
+		}
+		fmt.Println("✅ Project setup completed!")
+		return nil
+	}
+
 	// Run go mod tidy to download dependencies
 	fmt.Println("📥 Installing dependencies...")
 	if err := exec.Command("go", "mod", "tidy").Run(); err != nil {
@@ -429,74 +336,3 @@ func setupProject(opts *ProjectOptions) error {
 	fmt.Println("✅ Project setup completed!")
 	return nil
 }
-
-func showVersion() {
-	fmt.Printf("Galaplate CLI v%s\n", version)
-	fmt.Printf("Built: %s\n", date)
-	fmt.Printf("Commit: %s\n", commit)
-	fmt.Println("galaplate core: v0.0.0-local")
-}
-
-func listTemplates() error {
-	fmt.Println("📋 Available Templates:")
-	fmt.Println()
-	fmt.Println("  🔹 api     - REST API only (default)")
-	fmt.Println("           Features: HTTP server, Database, Auth, Jobs, Console")
-	fmt.Println("           Best for: Backend APIs, microservices")
-	fmt.Println()
-	fmt.Println("  🔹 full    - Full-stack application")
-	fmt.Println("           Features: API + Frontend templates + Static assets")
-	fmt.Println("           Best for: Web applications with UI")
-	fmt.Println()
-	fmt.Println("  🔹 micro   - Microservice template")
-	fmt.Println("           Features: Minimal API, Service discovery, Health checks")
-	fmt.Println("           Best for: Distributed systems, minimal services")
-	fmt.Println()
-	fmt.Println("Usage: galaplate new my-project --template=api")
-
-	return nil
-}
-
-func showHelp() {
-	fmt.Println(`🚀 Galaplate CLI - Go REST API Boilerplate Generator
-
-Usage:
-  galaplate <command> [arguments]
-
-Commands:
-  new <project-name>     Create a new Galaplate project
-  version               Show CLI version information
-  templates             List available project templates
-  help                  Show this help message
-
-Flags for 'new' command:
-  --template=<name>     Template to use (api, full, micro) [default: api]
-  --db=<type>          Database type (postgres, mysql) [default: postgres]
-  --module=<name>      Custom Go module name [default: project-name]
-  --no-git            Skip git initialization
-  --force             Overwrite existing directory
-
-Examples:
-  galaplate new my-api                                    # Simple API project
-  galaplate new my-app --template=full --db=mysql        # Full-stack with MySQL
-  galaplate new microservice --template=micro --no-git   # Microservice without git
-
-🛠️  After project creation:
-  cd my-api
-  cp .env.example .env        # Configure your environment
-  go mod tidy                 # Install dependencies
-  go run main.go console db:up  # Run database migrations
-  go run main.go              # Start the server
-
-🎯 Built-in Generators (available in your project):
-  go run main.go console make:model User
-  go run main.go console make:controller UserController  
-  go run main.go console make:job EmailNotification
-  go run main.go console make:dto UserCreateRequest
-  go run main.go console list                    # See all available commands
-
-💡 The CLI downloads project templates. All powerful generators are built into
-   the project itself via galaplate core's console system.
-
-For more information: https://github.com/galaplate/galaplate`)
-}
@@ -0,0 +1,367 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+const (
+	manifestJSONName = "galaplate.json"
+	manifestYAMLName = "galaplate.yaml"
+)
+
+// loadManifest reads galaplate.json (preferred) or galaplate.yaml from the
+// template root. A template without either file is still valid — it just
+// gets the legacy hardcoded include/exclude/replacement behavior.
+func loadManifest(srcRoot string) (*TemplateConfig, error) {
+	jsonPath := filepath.Join(srcRoot, manifestJSONName)
+	if data, err := os.ReadFile(jsonPath); err == nil {
+		var cfg TemplateConfig
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("parsing %s: %v", manifestJSONName, err)
+		}
+		return &cfg, nil
+	}
+
+	yamlPath := filepath.Join(srcRoot, manifestYAMLName)
+	if data, err := os.ReadFile(yamlPath); err == nil {
+		cfg, err := parseManifestYAML(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %v", manifestYAMLName, err)
+		}
+		return cfg, nil
+	}
+
+	return nil, nil
+}
+
+// parseManifestYAML handles the flat/nested subset of YAML a TemplateConfig
+// needs: top-level scalars, a "features"/"setup_steps" list, and a nested
+// "structure: {include, exclude}" block. It is a small hand-rolled reader
+// rather than a general YAML parser.
+func parseManifestYAML(data []byte) (*TemplateConfig, error) {
+	cfg := &TemplateConfig{
+		Dependencies: map[string]string{},
+		Replacements: map[string]string{},
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	var section, listKey string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "- ") {
+			item := strings.TrimSpace(strings.TrimPrefix(trimmed, "- "))
+			switch listKey {
+			case "features":
+				cfg.Features = append(cfg.Features, item)
+			case "setup_steps":
+				cfg.SetupSteps = append(cfg.SetupSteps, item)
+			case "include":
+				cfg.Structure.Include = append(cfg.Structure.Include, item)
+			case "exclude":
+				cfg.Structure.Exclude = append(cfg.Structure.Exclude, item)
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		key = strings.TrimSpace(key)
+
+		if indent == 0 {
+			section = ""
+		}
+
+		switch {
+		case key == "structure" && value == "":
+			section = "structure"
+			continue
+		case key == "replacements" && value == "":
+			section = "replacements"
+			continue
+		}
+
+		if section == "structure" {
+			listKey = key
+			continue
+		}
+		if section == "replacements" {
+			cfg.Replacements[key] = value
+			continue
+		}
+
+		switch key {
+		case "name":
+			cfg.Name = value
+		case "description":
+			cfg.Description = value
+		case "version":
+			cfg.Version = value
+		case "features":
+			listKey = "features"
+		case "setup_steps":
+			listKey = "setup_steps"
+		}
+	}
+
+	return cfg, scanner.Err()
+}
+
+// featuresEnabled parses --features=auth,jobs into a set for quick lookup.
+func featuresEnabled(raw string) map[string]bool {
+	enabled := map[string]bool{}
+	if raw == "" {
+		return enabled
+	}
+	for _, f := range strings.Split(raw, ",") {
+		f = strings.TrimSpace(f)
+		if f != "" {
+			enabled[f] = true
+		}
+	}
+	return enabled
+}
+
+// manifestAllows reports whether relPath should be copied given the
+// manifest's Structure.Include/Exclude globs. Include, if non-empty, is an
+// allowlist: the path must match at least one pattern. Exclude always wins.
+func manifestAllows(cfg *TemplateConfig, relPath string) bool {
+	if cfg == nil {
+		return true
+	}
+
+	for _, pattern := range cfg.Structure.Exclude {
+		if matchManifestPattern(pattern, relPath) {
+			return false
+		}
+	}
+
+	if len(cfg.Structure.Include) == 0 {
+		return true
+	}
+	for _, pattern := range cfg.Structure.Include {
+		if matchManifestPattern(pattern, relPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchManifestPattern matches relPath (and its base name) against pattern
+// using filepath.Match, plus a "**" extension: a "**" path segment matches
+// zero or more nested directories, so "templates/**" covers everything
+// under templates/ and "internal/**/*.go" covers .go files at any depth
+// under internal/ — patterns filepath.Match alone can't express since "*"
+// never crosses a "/".
+func matchManifestPattern(pattern, relPath string) bool {
+	relPath = filepath.ToSlash(relPath)
+	pattern = filepath.ToSlash(pattern)
+
+	if matched, _ := filepath.Match(pattern, relPath); matched {
+		return true
+	}
+	if matched, _ := filepath.Match(pattern, filepath.Base(relPath)); matched {
+		return true
+	}
+	if !strings.Contains(pattern, "**") {
+		return false
+	}
+
+	re, err := regexp.Compile("^" + globToRegex(pattern) + "$")
+	if err != nil {
+		return false
+	}
+	return re.MatchString(relPath)
+}
+
+// globToRegex translates a "**"-aware glob into an equivalent regexp:
+// "**/" and "/**" match zero-or-more path segments (so the boundary slash
+// is optional too), a lone "**" matches anything, and "*"/"?" behave as
+// usual but never cross a "/".
+func globToRegex(pattern string) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString(`(?:.*/)?`)
+			i += 3
+		case strings.HasPrefix(pattern[i:], "/**"):
+			b.WriteString(`(?:/.*)?`)
+			i += 3
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(`.*`)
+			i += 2
+		case pattern[i] == '*':
+			b.WriteString(`[^/]*`)
+			i++
+		case pattern[i] == '?':
+			b.WriteString(`[^/]`)
+			i++
+		case strings.ContainsRune(`.+()|^$[]{}\`, rune(pattern[i])):
+			b.WriteByte('\\')
+			b.WriteByte(pattern[i])
+			i++
+		default:
+			b.WriteByte(pattern[i])
+			i++
+		}
+	}
+	return b.String()
+}
+
+// renderTemplate executes a .template file's contents through text/template
+// with a Sprig-flavored function set plus the legacy PROJECT_NAME /
+// MODULE_NAME / DB_TYPE bare identifiers (registered as zero-arg functions)
+// so existing templates keep working unmodified.
+func renderTemplate(content string, opts *ProjectOptions, cfg *TemplateConfig, enabled map[string]bool) (string, error) {
+	funcs := template.FuncMap{
+		"PROJECT_NAME":           func() string { return opts.Name },
+		"MODULE_NAME":            func() string { return opts.Module },
+		"DB_TYPE":                func() string { return opts.DbType },
+		"GALAPLATE_CORE_VERSION": func() string { return "v0.0.0" },
+
+		"featureEnabled": func(name string) bool { return enabled[name] },
+		"snakecase":      snakeCase,
+		"camelcase":      camelCase,
+		"upper":          strings.ToUpper,
+		"lower":          strings.ToLower,
+		"title":          strings.Title,
+		"trim":           strings.TrimSpace,
+		"replace":        func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"uuid":           newUUID,
+	}
+
+	data := map[string]any{
+		"ProjectName": opts.Name,
+		"ModuleName":  opts.Module,
+		"DbType":      opts.DbType,
+		"Features":    enabled,
+	}
+	if cfg != nil {
+		for k, v := range cfg.Replacements {
+			data[k] = v
+		}
+	}
+
+	tmpl, err := template.New("template").Funcs(funcs).Parse(content)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+var snakeBoundary = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+func snakeCase(s string) string {
+	s = snakeBoundary.ReplaceAllString(s, "${1}_${2}")
+	s = strings.ReplaceAll(s, "-", "_")
+	s = strings.ReplaceAll(s, " ", "_")
+	return strings.ToLower(s)
+}
+
+func camelCase(s string) string {
+	parts := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '_' || r == '-' || r == ' '
+	})
+	for i, p := range parts {
+		if i == 0 {
+			parts[i] = strings.ToLower(p)
+			continue
+		}
+		parts[i] = string(unicode.ToUpper(rune(p[0]))) + strings.ToLower(p[1:])
+	}
+	return strings.Join(parts, "")
+}
+
+// newUUID generates a random (version 4) UUID for templates that need a
+// unique identifier at generation time.
+func newUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "00000000-0000-4000-8000-000000000000"
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// runSetupSteps executes a manifest's setup_steps as shell commands,
+// confirming with the user first since these are arbitrary commands from a
+// downloaded template.
+func runSetupSteps(steps []string) error {
+	if len(steps) == 0 {
+		return nil
+	}
+
+	fmt.Println("🔧 This template defines custom setup steps:")
+	for _, step := range steps {
+		fmt.Printf("   $ %s\n", step)
+	}
+	if !promptYesNo(bufio.NewReader(os.Stdin), "Run these commands?", false) {
+		fmt.Println("⏭️  Skipped template setup steps")
+		return nil
+	}
+
+	for _, step := range steps {
+		fmt.Printf("▶️  %s\n", step)
+		cmd := shellCommand(step)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("setup step %q failed: %v", step, err)
+		}
+	}
+	return nil
+}
+
+// shellCommand wraps a setup step string in the platform shell so steps can
+// use pipes, globs, and env vars the way a shell script would.
+func shellCommand(step string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", step)
+	}
+	return exec.Command("sh", "-c", step)
+}
+
+// requiredFeatures returns the subset of cfg.Features not present in
+// enabled, used to warn about toggles the manifest declares that nobody
+// asked for via --features.
+func requiredFeatures(cfg *TemplateConfig, enabled map[string]bool) []string {
+	if cfg == nil {
+		return nil
+	}
+	var missing []string
+	for _, f := range cfg.Features {
+		if !enabled[f] && !slices.Contains(missing, f) {
+			missing = append(missing, f)
+		}
+	}
+	return missing
+}
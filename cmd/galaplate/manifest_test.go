@@ -0,0 +1,133 @@
+package main
+
+import "testing"
+
+func TestManifestAllowsRecursiveGlobs(t *testing.T) {
+	cfg := &TemplateConfig{}
+	cfg.Structure.Include = []string{"templates/**"}
+
+	for _, path := range []string{"templates/sub/file.go", "templates/file.go", "templates"} {
+		if !manifestAllows(cfg, path) {
+			t.Errorf("manifestAllows(%q) = false, want true for pattern %q", path, cfg.Structure.Include[0])
+		}
+	}
+	if manifestAllows(cfg, "other/file.go") {
+		t.Error("manifestAllows(\"other/file.go\") = true, want false (not under templates/)")
+	}
+}
+
+func TestManifestAllowsNestedGlobSuffix(t *testing.T) {
+	cfg := &TemplateConfig{}
+	cfg.Structure.Include = []string{"internal/**/*.go"}
+
+	for _, path := range []string{"internal/foo.go", "internal/sub/foo.go", "internal/sub/deep/foo.go"} {
+		if !manifestAllows(cfg, path) {
+			t.Errorf("manifestAllows(%q) = false, want true for pattern %q", path, cfg.Structure.Include[0])
+		}
+	}
+	if manifestAllows(cfg, "internal/foo.txt") {
+		t.Error("manifestAllows(\"internal/foo.txt\") = true, want false (wrong extension)")
+	}
+}
+
+func TestManifestAllowsExcludeWins(t *testing.T) {
+	cfg := &TemplateConfig{}
+	cfg.Structure.Exclude = []string{"vendor/**"}
+
+	if manifestAllows(cfg, "vendor/pkg/file.go") {
+		t.Error("manifestAllows should exclude anything under vendor/")
+	}
+	if !manifestAllows(cfg, "app/file.go") {
+		t.Error("manifestAllows should allow paths outside an exclude pattern when there's no include list")
+	}
+
+	cfg.Structure.Include = []string{"vendor/**"}
+	if manifestAllows(cfg, "vendor/pkg/file.go") {
+		t.Error("manifestAllows should let Exclude win over a matching Include pattern")
+	}
+}
+
+func TestManifestAllowsNoConfig(t *testing.T) {
+	if !manifestAllows(nil, "anything/at/all.go") {
+		t.Error("manifestAllows(nil, ...) should always allow")
+	}
+}
+
+func TestParseManifestYAML(t *testing.T) {
+	data := []byte(`
+name: api
+description: REST API template
+version: 1.0.0
+features:
+  - auth
+  - jobs
+structure:
+  include:
+    - templates/**
+  exclude:
+    - vendor/**
+setup_steps:
+  - go mod tidy
+`)
+
+	cfg, err := parseManifestYAML(data)
+	if err != nil {
+		t.Fatalf("parseManifestYAML: %v", err)
+	}
+
+	if cfg.Name != "api" || cfg.Description != "REST API template" || cfg.Version != "1.0.0" {
+		t.Fatalf("unexpected scalars: %+v", cfg)
+	}
+	if len(cfg.Features) != 2 || cfg.Features[0] != "auth" || cfg.Features[1] != "jobs" {
+		t.Fatalf("unexpected features: %v", cfg.Features)
+	}
+	if len(cfg.Structure.Include) != 1 || cfg.Structure.Include[0] != "templates/**" {
+		t.Fatalf("unexpected include: %v", cfg.Structure.Include)
+	}
+	if len(cfg.Structure.Exclude) != 1 || cfg.Structure.Exclude[0] != "vendor/**" {
+		t.Fatalf("unexpected exclude: %v", cfg.Structure.Exclude)
+	}
+	if len(cfg.SetupSteps) != 1 || cfg.SetupSteps[0] != "go mod tidy" {
+		t.Fatalf("unexpected setup_steps: %v", cfg.SetupSteps)
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	cases := map[string]string{
+		"UserController": "user_controller",
+		"my-thing":       "my_thing",
+		"already_snake":  "already_snake",
+		"Some Title":     "some_title",
+	}
+	for in, want := range cases {
+		if got := snakeCase(in); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCamelCase(t *testing.T) {
+	cases := map[string]string{
+		"user_controller": "userController",
+		"my-thing":        "myThing",
+		"already":         "already",
+	}
+	for in, want := range cases {
+		if got := camelCase(in); got != want {
+			t.Errorf("camelCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFeaturesEnabled(t *testing.T) {
+	enabled := featuresEnabled("auth, jobs ,")
+	if !enabled["auth"] || !enabled["jobs"] {
+		t.Fatalf("expected auth and jobs enabled, got %v", enabled)
+	}
+	if enabled["websocket"] {
+		t.Fatal("websocket should not be enabled")
+	}
+	if len(featuresEnabled("")) != 0 {
+		t.Fatal("empty input should enable nothing")
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Profile is a saved set of answers from the interactive wizard, persisted
+// as YAML under ~/.config/galaplate/profiles so `--profile=<name>` can
+// regenerate a project without re-answering every prompt.
+type Profile struct {
+	Name     string   `yaml:"-"`
+	Template string   `yaml:"template"`
+	DbType   string   `yaml:"db"`
+	Module   string   `yaml:"module"`
+	Features []string `yaml:"features"`
+	NoGit    bool     `yaml:"no_git"`
+}
+
+// profilesDir returns ~/.config/galaplate/profiles, creating it if needed.
+func profilesDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(home, ".config", "galaplate", "profiles")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+func profilePath(name string) (string, error) {
+	dir, err := profilesDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".yaml"), nil
+}
+
+// SaveProfile writes p as a YAML document under ~/.config/galaplate/profiles.
+func SaveProfile(name string, p *Profile) error {
+	path, err := profilePath(name)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("encoding profile %q: %v", name, err)
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadProfile reads back a profile saved by SaveProfile.
+func LoadProfile(name string) (*Profile, error) {
+	path, err := profilePath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("profile %q not found: %v", name, err)
+	}
+
+	p := &Profile{Name: name}
+	if err := yaml.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("parsing profile %q: %v", name, err)
+	}
+
+	return p, nil
+}
+
+// ListProfiles returns the names of all saved profiles, sorted.
+func ListProfiles() ([]string, error) {
+	dir, err := profilesDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".yaml") {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(e.Name(), ".yaml"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSaveLoadProfileRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	want := &Profile{
+		Template: "full",
+		DbType:   "mysql",
+		Module:   "github.com/acme/widgets",
+		Features: []string{"auth", "jobs"},
+		NoGit:    true,
+	}
+
+	if err := SaveProfile("work", want); err != nil {
+		t.Fatalf("SaveProfile: %v", err)
+	}
+
+	got, err := LoadProfile("work")
+	if err != nil {
+		t.Fatalf("LoadProfile: %v", err)
+	}
+	got.Name = ""
+
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("round trip mismatch:\n got:  %+v\n want: %+v", got, want)
+	}
+}
+
+func TestSaveLoadProfileNoFeatures(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	want := &Profile{Template: "api", DbType: "postgres", Module: "acme/api"}
+	if err := SaveProfile("minimal", want); err != nil {
+		t.Fatalf("SaveProfile: %v", err)
+	}
+
+	got, err := LoadProfile("minimal")
+	if err != nil {
+		t.Fatalf("LoadProfile: %v", err)
+	}
+	if len(got.Features) != 0 {
+		t.Fatalf("expected no features, got %v", got.Features)
+	}
+}
+
+func TestLoadProfileNotFound(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if _, err := LoadProfile("does-not-exist"); err == nil {
+		t.Fatal("expected an error loading a profile that was never saved")
+	}
+}
+
+func TestListProfiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if err := SaveProfile("b-profile", &Profile{Template: "api"}); err != nil {
+		t.Fatalf("SaveProfile: %v", err)
+	}
+	if err := SaveProfile("a-profile", &Profile{Template: "full"}); err != nil {
+		t.Fatalf("SaveProfile: %v", err)
+	}
+
+	names, err := ListProfiles()
+	if err != nil {
+		t.Fatalf("ListProfiles: %v", err)
+	}
+	want := []string{"a-profile", "b-profile"}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("ListProfiles() = %v, want %v", names, want)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "galaplate",
+	Short: "Go REST API Boilerplate Generator",
+	Long: `🚀 Galaplate CLI - Go REST API Boilerplate Generator
+
+The CLI downloads project templates. All powerful generators are built into
+the project itself via galaplate core's console system.
+
+For more information: https://github.com/galaplate/galaplate`,
+}
+
+func init() {
+	rootCmd.AddCommand(
+		newNewCmd(),
+		newVersionCmd(),
+		newTemplatesCmd(),
+		newUpgradeCmd(),
+		newDoctorCmd(),
+		newProfilesCmd(),
+		newCompletionCmd(),
+	)
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,466 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// TemplateSource resolves a project template into a local directory that can
+// be copied from by copyProjectFiles. Implementations are responsible for
+// their own caching/cleanup; callers must remove the returned directory when
+// it is safe to do so (Cleanup handles that).
+type TemplateSource interface {
+	// Fetch resolves the template and returns the local directory it was
+	// placed in.
+	Fetch() (string, error)
+	// Cleanup removes any temporary state created by Fetch. It is always
+	// safe to call, even if Fetch was never called or failed.
+	Cleanup()
+}
+
+// SourceOptions configures how a template source is resolved. Not every
+// field applies to every source kind.
+type SourceOptions struct {
+	// Raw is the value passed via --source, e.g. "github:owner/repo",
+	// "gitea:host/owner/repo", "gitlab:owner/repo", "git+ssh://...", or a
+	// bare owner/repo (defaults to GitHub).
+	Raw string
+	// Ref is a branch, tag, or commit SHA to pin to (--ref). A git URL
+	// source clones with --branch, which takes a branch or tag but not a
+	// commit SHA. Empty means "main" for GitHub, Gitea and GitLab sources,
+	// and the remote's default branch for a git URL.
+	Ref string
+	// Local is a filesystem path to a template directory (--local).
+	Local string
+	// NoCache disables reading/writing the template cache under
+	// ~/.galaplate/cache.
+	NoCache bool
+}
+
+// cacheDir returns ~/.galaplate/cache, creating it if necessary.
+func cacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(home, ".galaplate", "cache")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// cacheKey derives a filesystem-safe cache directory name for a resolved
+// source + ref pair.
+func cacheKey(source, ref string) string {
+	sum := sha256.Sum256([]byte(source + "@" + ref))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// ResolveTemplateSource picks the right TemplateSource implementation for
+// the given options, in the spirit of golang.org/x/tools/go/vcs's
+// prefix-based detection: explicit scheme/host prefixes win, otherwise we
+// fall back to GitHub for a bare "owner/repo".
+func ResolveTemplateSource(opts SourceOptions) (TemplateSource, error) {
+	if opts.Local != "" {
+		return &localSource{path: opts.Local}, nil
+	}
+
+	raw := opts.Raw
+	if raw == "" {
+		raw = "galaplate/galaplate"
+	}
+
+	switch {
+	case strings.HasPrefix(raw, "github:"):
+		owner, repo, err := splitOwnerRepo(strings.TrimPrefix(raw, "github:"))
+		if err != nil {
+			return nil, err
+		}
+		return &githubSource{owner: owner, repo: repo, ref: opts.Ref, noCache: opts.NoCache}, nil
+
+	case strings.HasPrefix(raw, "gitea:"):
+		host, owner, repo, err := splitHostOwnerRepo(strings.TrimPrefix(raw, "gitea:"))
+		if err != nil {
+			return nil, err
+		}
+		return &giteaSource{host: host, owner: owner, repo: repo, ref: opts.Ref, noCache: opts.NoCache}, nil
+
+	case strings.HasPrefix(raw, "gitlab:"):
+		owner, repo, err := splitOwnerRepo(strings.TrimPrefix(raw, "gitlab:"))
+		if err != nil {
+			return nil, err
+		}
+		return &gitlabSource{owner: owner, repo: repo, ref: opts.Ref, noCache: opts.NoCache}, nil
+
+	case strings.Contains(raw, "://") || strings.HasPrefix(raw, "git@") || strings.HasSuffix(raw, ".git"):
+		return &gitCloneSource{url: raw, ref: opts.Ref, noCache: opts.NoCache}, nil
+
+	default:
+		owner, repo, err := splitOwnerRepo(raw)
+		if err != nil {
+			return nil, err
+		}
+		return &githubSource{owner: owner, repo: repo, ref: opts.Ref, noCache: opts.NoCache}, nil
+	}
+}
+
+func splitOwnerRepo(s string) (owner, repo string, err error) {
+	parts := strings.SplitN(s, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("expected \"owner/repo\", got %q", s)
+	}
+	return parts[0], parts[1], nil
+}
+
+func splitHostOwnerRepo(s string) (host, owner, repo string, err error) {
+	parts := strings.SplitN(s, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("expected \"host/owner/repo\", got %q", s)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+// localSource copies a template straight from the local filesystem. No
+// caching and no cleanup since the caller doesn't own the directory.
+type localSource struct {
+	path string
+}
+
+func (s *localSource) Fetch() (string, error) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return "", fmt.Errorf("local template path %q: %v", s.path, err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("local template path %q is not a directory", s.path)
+	}
+	return s.path, nil
+}
+
+func (s *localSource) Cleanup() {}
+
+// githubSource downloads a tagged release (or branch) tarball from GitHub.
+type githubSource struct {
+	owner, repo, ref string
+	noCache          bool
+	tempDir          string
+}
+
+func (s *githubSource) Fetch() (string, error) {
+	ref := s.ref
+	if ref == "" {
+		ref = "main"
+	}
+
+	if !s.noCache {
+		if dir, ok := cachedTemplate(s.owner+"/"+s.repo, ref); ok {
+			return dir, nil
+		}
+	}
+
+	// Tags/releases and branches live under different archive URLs; with an
+	// explicit ref, try the tag/release form first, then the branch form,
+	// then the plain form GitHub also serves for commit SHAs.
+	urls := []string{
+		fmt.Sprintf("https://github.com/%s/%s/archive/refs/tags/%s.tar.gz", s.owner, s.repo, ref),
+		fmt.Sprintf("https://github.com/%s/%s/archive/refs/heads/%s.tar.gz", s.owner, s.repo, ref),
+		fmt.Sprintf("https://github.com/%s/%s/archive/%s.tar.gz", s.owner, s.repo, ref),
+	}
+	if s.ref == "" {
+		urls = urls[1:2]
+	}
+
+	var lastErr error
+	for _, url := range urls {
+		dir, err := downloadTarGz(url)
+		if err == nil {
+			s.tempDir = dir
+			if !s.noCache {
+				cacheTemplate(s.owner+"/"+s.repo, ref, dir)
+			}
+			return dir, nil
+		}
+		lastErr = err
+	}
+	return "", fmt.Errorf("failed to download %s/%s@%s: %v", s.owner, s.repo, ref, lastErr)
+}
+
+func (s *githubSource) Cleanup() {
+	if s.tempDir != "" {
+		os.RemoveAll(s.tempDir)
+	}
+}
+
+// giteaSource downloads a branch/tag archive from a self-hosted Gitea
+// instance, which mirrors GitHub's archive URL shape.
+type giteaSource struct {
+	host, owner, repo, ref string
+	noCache                bool
+	tempDir                string
+}
+
+func (s *giteaSource) Fetch() (string, error) {
+	ref := s.ref
+	if ref == "" {
+		ref = "main"
+	}
+
+	if !s.noCache {
+		if dir, ok := cachedTemplate(s.host+"/"+s.owner+"/"+s.repo, ref); ok {
+			return dir, nil
+		}
+	}
+
+	url := fmt.Sprintf("https://%s/%s/%s/archive/%s.tar.gz", s.host, s.owner, s.repo, ref)
+	dir, err := downloadTarGz(url)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s/%s/%s@%s: %v", s.host, s.owner, s.repo, ref, err)
+	}
+	s.tempDir = dir
+	if !s.noCache {
+		cacheTemplate(s.host+"/"+s.owner+"/"+s.repo, ref, dir)
+	}
+	return dir, nil
+}
+
+func (s *giteaSource) Cleanup() {
+	if s.tempDir != "" {
+		os.RemoveAll(s.tempDir)
+	}
+}
+
+// gitlabSource downloads a branch/tag archive from gitlab.com.
+type gitlabSource struct {
+	owner, repo, ref string
+	noCache          bool
+	tempDir          string
+}
+
+func (s *gitlabSource) Fetch() (string, error) {
+	ref := s.ref
+	if ref == "" {
+		ref = "main"
+	}
+
+	if !s.noCache {
+		if dir, ok := cachedTemplate("gitlab.com/"+s.owner+"/"+s.repo, ref); ok {
+			return dir, nil
+		}
+	}
+
+	url := fmt.Sprintf("https://gitlab.com/%s/%s/-/archive/%s/%s-%s.tar.gz", s.owner, s.repo, ref, s.repo, ref)
+	dir, err := downloadTarGz(url)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s/%s@%s: %v", s.owner, s.repo, ref, err)
+	}
+	s.tempDir = dir
+	if !s.noCache {
+		cacheTemplate("gitlab.com/"+s.owner+"/"+s.repo, ref, dir)
+	}
+	return dir, nil
+}
+
+func (s *gitlabSource) Cleanup() {
+	if s.tempDir != "" {
+		os.RemoveAll(s.tempDir)
+	}
+}
+
+// gitCloneSource shells out to `git clone` for any VCS URL not covered by
+// the dedicated hosts above (private repos, mirrors, git+ssh, etc).
+type gitCloneSource struct {
+	url, ref string
+	noCache  bool
+	tempDir  string
+}
+
+func (s *gitCloneSource) Fetch() (string, error) {
+	url := strings.TrimPrefix(s.url, "git+")
+
+	if !s.noCache {
+		if dir, ok := cachedTemplate(url, s.ref); ok {
+			return dir, nil
+		}
+	}
+
+	tempDir, err := os.MkdirTemp("", "galaplate-")
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{"clone", "--depth=1"}
+	if s.ref != "" {
+		args = append(args, "--branch", s.ref)
+	}
+	args = append(args, url, tempDir)
+
+	cmd := exec.Command("git", args...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		os.RemoveAll(tempDir)
+		return "", fmt.Errorf("git clone %s: %v", url, err)
+	}
+	os.RemoveAll(filepath.Join(tempDir, ".git"))
+
+	s.tempDir = tempDir
+	if !s.noCache {
+		cacheTemplate(url, s.ref, tempDir)
+	}
+	return tempDir, nil
+}
+
+func (s *gitCloneSource) Cleanup() {
+	if s.tempDir != "" {
+		os.RemoveAll(s.tempDir)
+	}
+}
+
+// downloadTarGz fetches url and extracts it into a fresh temp directory,
+// stripping the single top-level directory archives like GitHub's contain.
+func downloadTarGz(url string) (string, error) {
+	tempDir, err := os.MkdirTemp("", "galaplate-")
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := http.Get(url)
+	if err != nil {
+		os.RemoveAll(tempDir)
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		os.RemoveAll(tempDir)
+		return "", fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	gzr, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		os.RemoveAll(tempDir)
+		return "", err
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			os.RemoveAll(tempDir)
+			return "", err
+		}
+
+		parts := strings.Split(header.Name, "/")
+		if len(parts) <= 1 {
+			continue
+		}
+		path := filepath.Join(tempDir, filepath.Join(parts[1:]...))
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, os.FileMode(header.Mode)); err != nil {
+				os.RemoveAll(tempDir)
+				return "", err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				os.RemoveAll(tempDir)
+				return "", err
+			}
+			file, err := os.Create(path)
+			if err != nil {
+				os.RemoveAll(tempDir)
+				return "", err
+			}
+			if _, err := io.Copy(file, tr); err != nil {
+				file.Close()
+				os.RemoveAll(tempDir)
+				return "", err
+			}
+			file.Close()
+			if err := os.Chmod(path, os.FileMode(header.Mode)); err != nil {
+				os.RemoveAll(tempDir)
+				return "", err
+			}
+		}
+	}
+
+	return tempDir, nil
+}
+
+// cachedTemplate returns the cached directory for source@ref if it exists.
+func cachedTemplate(source, ref string) (string, bool) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", false
+	}
+	entry := filepath.Join(dir, cacheKey(source, ref))
+	if info, err := os.Stat(entry); err == nil && info.IsDir() {
+		return entry, true
+	}
+	return "", false
+}
+
+// cacheTemplate copies a resolved template directory into the cache so
+// future `new` invocations for the same source@ref skip the network.
+func cacheTemplate(source, ref, dir string) {
+	cache, err := cacheDir()
+	if err != nil {
+		return
+	}
+	entry := filepath.Join(cache, cacheKey(source, ref))
+	os.RemoveAll(entry)
+	if err := copyDir(dir, entry); err != nil {
+		os.RemoveAll(entry)
+	}
+}
+
+// copyDir recursively copies src into dest, preserving file modes.
+func copyDir(src, dest string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		target := filepath.Join(dest, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, info.Mode())
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		out, err := os.Create(target)
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+		_, err = io.Copy(out, in)
+		return err
+	})
+}
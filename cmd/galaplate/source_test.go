@@ -0,0 +1,116 @@
+package main
+
+import "testing"
+
+func TestCacheKeyDeterministicAndDistinct(t *testing.T) {
+	a := cacheKey("galaplate/galaplate", "main")
+	b := cacheKey("galaplate/galaplate", "main")
+	if a != b {
+		t.Fatalf("cacheKey is not deterministic: %q != %q", a, b)
+	}
+
+	c := cacheKey("galaplate/galaplate", "v1.0.0")
+	if a == c {
+		t.Fatalf("cacheKey collided for different refs: %q", a)
+	}
+
+	if len(a) != 16 {
+		t.Fatalf("expected a 16-char cache key, got %d: %q", len(a), a)
+	}
+}
+
+func TestSplitOwnerRepo(t *testing.T) {
+	owner, repo, err := splitOwnerRepo("galaplate/galaplate")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if owner != "galaplate" || repo != "galaplate" {
+		t.Fatalf("got owner=%q repo=%q", owner, repo)
+	}
+
+	for _, bad := range []string{"galaplate", "/repo", "owner/", ""} {
+		if _, _, err := splitOwnerRepo(bad); err == nil {
+			t.Errorf("splitOwnerRepo(%q) expected an error, got nil", bad)
+		}
+	}
+}
+
+func TestSplitHostOwnerRepo(t *testing.T) {
+	host, owner, repo, err := splitHostOwnerRepo("gitea.example.com/acme/widgets")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if host != "gitea.example.com" || owner != "acme" || repo != "widgets" {
+		t.Fatalf("got host=%q owner=%q repo=%q", host, owner, repo)
+	}
+
+	for _, bad := range []string{"owner/repo", "host/owner", ""} {
+		if _, _, _, err := splitHostOwnerRepo(bad); err == nil {
+			t.Errorf("splitHostOwnerRepo(%q) expected an error, got nil", bad)
+		}
+	}
+}
+
+func TestResolveTemplateSourcePrefixDispatch(t *testing.T) {
+	cases := []struct {
+		raw  string
+		want any
+	}{
+		{"github:galaplate/galaplate", &githubSource{}},
+		{"gitea:git.example.com/acme/widgets", &giteaSource{}},
+		{"gitlab:acme/widgets", &gitlabSource{}},
+		{"git@github.com:acme/widgets.git", &gitCloneSource{}},
+		{"https://example.com/acme/widgets.git", &gitCloneSource{}},
+		{"acme/widgets", &githubSource{}},
+		{"", &githubSource{}},
+	}
+
+	for _, tc := range cases {
+		src, err := ResolveTemplateSource(SourceOptions{Raw: tc.raw})
+		if err != nil {
+			t.Errorf("ResolveTemplateSource(%q) unexpected error: %v", tc.raw, err)
+			continue
+		}
+		switch tc.want.(type) {
+		case *githubSource:
+			if _, ok := src.(*githubSource); !ok {
+				t.Errorf("ResolveTemplateSource(%q) = %T, want *githubSource", tc.raw, src)
+			}
+		case *giteaSource:
+			if _, ok := src.(*giteaSource); !ok {
+				t.Errorf("ResolveTemplateSource(%q) = %T, want *giteaSource", tc.raw, src)
+			}
+		case *gitlabSource:
+			if _, ok := src.(*gitlabSource); !ok {
+				t.Errorf("ResolveTemplateSource(%q) = %T, want *gitlabSource", tc.raw, src)
+			}
+		case *gitCloneSource:
+			if _, ok := src.(*gitCloneSource); !ok {
+				t.Errorf("ResolveTemplateSource(%q) = %T, want *gitCloneSource", tc.raw, src)
+			}
+		}
+	}
+
+	if _, err := ResolveTemplateSource(SourceOptions{Raw: "github:not-owner-slash-repo"}); err == nil {
+		t.Error("expected an error for a malformed github: source")
+	}
+}
+
+func TestResolveTemplateSourceLocal(t *testing.T) {
+	dir := t.TempDir()
+	src, err := ResolveTemplateSource(SourceOptions{Local: dir})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := src.(*localSource); !ok {
+		t.Fatalf("got %T, want *localSource", src)
+	}
+
+	got, err := src.Fetch()
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if got != dir {
+		t.Fatalf("Fetch() = %q, want %q", got, dir)
+	}
+}
@@ -0,0 +1,448 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const upgradeRepo = "galaplate/cli"
+
+// githubRelease is the subset of the GitHub Releases API response we need.
+type githubRelease struct {
+	TagName    string `json:"tag_name"`
+	Prerelease bool   `json:"prerelease"`
+	Assets     []struct {
+		Name               string `json:"name"`
+		BrowserDownloadURL string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+// UpgradeOptions configures `galaplate upgrade`.
+type UpgradeOptions struct {
+	CheckOnly bool
+	Pre       bool
+	Version   string
+	Rollback  bool
+}
+
+func newUpgradeCmd() *cobra.Command {
+	opts := &UpgradeOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "upgrade",
+		Aliases: []string{"self-update"},
+		Short:   "Upgrade the CLI to the latest GitHub release",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUpgrade(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.CheckOnly, "check", false, "Report whether a newer version exists without installing it")
+	flags.BoolVar(&opts.Pre, "pre", false, "Include prereleases when checking for updates")
+	flags.StringVar(&opts.Version, "version", "", "Install a specific version instead of the latest")
+	flags.BoolVar(&opts.Rollback, "rollback", false, "Restore the binary backed up by the previous upgrade")
+
+	return cmd
+}
+
+func runUpgrade(opts *UpgradeOptions) error {
+	if opts.Rollback {
+		return rollbackUpgrade()
+	}
+
+	release, err := fetchRelease(opts)
+	if err != nil {
+		return fmt.Errorf("failed to check for updates: %v", err)
+	}
+
+	current := strings.TrimPrefix(version, "v")
+	latest := strings.TrimPrefix(release.TagName, "v")
+
+	if latest == current || (opts.Version == "" && compareVersions(latest, current) < 0) {
+		fmt.Printf("✅ Already up to date (v%s)\n", current)
+		return nil
+	}
+
+	fmt.Printf("⬆️  Update available: v%s -> v%s\n", current, latest)
+	if opts.CheckOnly {
+		return nil
+	}
+
+	asset, err := findAsset(release)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("📥 Downloading %s...\n", asset.Name)
+	archivePath, err := downloadToTemp(asset.BrowserDownloadURL)
+	if err != nil {
+		return fmt.Errorf("failed to download release asset: %v", err)
+	}
+	defer os.Remove(archivePath)
+
+	sum, err := releaseChecksum(release, asset.Name)
+	if err != nil {
+		return fmt.Errorf("failed to fetch checksums: %v", err)
+	}
+	if sum != "" {
+		if err := verifyChecksum(archivePath, sum); err != nil {
+			return err
+		}
+		fmt.Println("🔒 Checksum verified")
+	} else {
+		fmt.Println("⚠️  Warning: no checksum found for this asset, skipping verification")
+	}
+
+	binaryPath, err := extractBinary(archivePath, asset.Name)
+	if err != nil {
+		return fmt.Errorf("failed to extract release binary: %v", err)
+	}
+	defer os.Remove(binaryPath)
+
+	if err := replaceRunningBinary(binaryPath); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Upgraded to v%s\n", latest)
+	return reExecVersion()
+}
+
+// reExecVersion runs the freshly-installed binary's `version` subcommand so
+// the user sees the upgrade actually took effect.
+func reExecVersion() error {
+	self, err := os.Executable()
+	if err != nil {
+		return nil
+	}
+	cmd := exec.Command(self, "version")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// fetchRelease returns the release matching opts: a pinned --version, the
+// latest release, or the latest including prereleases with --pre.
+func fetchRelease(opts *UpgradeOptions) (*githubRelease, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", upgradeRepo)
+	if opts.Version != "" {
+		url = fmt.Sprintf("https://api.github.com/repos/%s/releases/tags/%s", upgradeRepo, opts.Version)
+	} else if opts.Pre {
+		url = fmt.Sprintf("https://api.github.com/repos/%s/releases", upgradeRepo)
+	}
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	if opts.Pre && opts.Version == "" {
+		var releases []githubRelease
+		if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+			return nil, err
+		}
+		if len(releases) == 0 {
+			return nil, fmt.Errorf("no releases found")
+		}
+		return &releases[0], nil
+	}
+
+	var release githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
+
+// assetInfo names the release asset chosen for the current platform.
+type assetInfo struct {
+	Name               string
+	BrowserDownloadURL string
+}
+
+func findAsset(release *githubRelease) (*assetInfo, error) {
+	suffixes := []string{
+		fmt.Sprintf("%s_%s.tar.gz", runtime.GOOS, runtime.GOARCH),
+		fmt.Sprintf("%s_%s.zip", runtime.GOOS, runtime.GOARCH),
+	}
+	for _, a := range release.Assets {
+		for _, suffix := range suffixes {
+			if strings.HasSuffix(a.Name, suffix) {
+				return &assetInfo{Name: a.Name, BrowserDownloadURL: a.BrowserDownloadURL}, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no release asset found for %s/%s", runtime.GOOS, runtime.GOARCH)
+}
+
+func downloadToTemp(url string) (string, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	f, err := os.CreateTemp("", "galaplate-upgrade-*")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// releaseChecksum looks up assetName's SHA256 from the release's
+// checksums.txt asset, if present.
+func releaseChecksum(release *githubRelease, assetName string) (string, error) {
+	var checksumsURL string
+	for _, a := range release.Assets {
+		if a.Name == "checksums.txt" {
+			checksumsURL = a.BrowserDownloadURL
+			break
+		}
+	}
+	if checksumsURL == "" {
+		return "", nil
+	}
+
+	resp, err := http.Get(checksumsURL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[1] == assetName {
+			return fields[0], nil
+		}
+	}
+	return "", nil
+}
+
+func verifyChecksum(path, want string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	got := hex.EncodeToString(h.Sum(nil))
+	if got != want {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", want, got)
+	}
+	return nil
+}
+
+// extractBinary pulls the galaplate executable out of a downloaded
+// tar.gz/zip archive into a fresh temp file.
+func extractBinary(archivePath, assetName string) (string, error) {
+	binName := "galaplate"
+	if runtime.GOOS == "windows" {
+		binName += ".exe"
+	}
+
+	if strings.HasSuffix(assetName, ".zip") {
+		return extractFromZip(archivePath, binName)
+	}
+	return extractFromTarGz(archivePath, binName)
+}
+
+func extractFromTarGz(archivePath, binName string) (string, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	gzr, err := gzip.NewReader(f)
+	if err != nil {
+		return "", err
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if filepath.Base(header.Name) != binName {
+			continue
+		}
+
+		out, err := os.CreateTemp("", "galaplate-bin-*")
+		if err != nil {
+			return "", err
+		}
+		defer out.Close()
+
+		if _, err := io.Copy(out, tr); err != nil {
+			os.Remove(out.Name())
+			return "", err
+		}
+		if err := os.Chmod(out.Name(), 0755); err != nil {
+			return "", err
+		}
+		return out.Name(), nil
+	}
+	return "", fmt.Errorf("binary %q not found in archive", binName)
+}
+
+func extractFromZip(archivePath, binName string) (string, error) {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return "", err
+	}
+	defer zr.Close()
+
+	for _, file := range zr.File {
+		if filepath.Base(file.Name) != binName {
+			continue
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			return "", err
+		}
+		defer rc.Close()
+
+		out, err := os.CreateTemp("", "galaplate-bin-*")
+		if err != nil {
+			return "", err
+		}
+		defer out.Close()
+
+		if _, err := io.Copy(out, rc); err != nil {
+			os.Remove(out.Name())
+			return "", err
+		}
+		if err := os.Chmod(out.Name(), 0755); err != nil {
+			return "", err
+		}
+		return out.Name(), nil
+	}
+	return "", fmt.Errorf("binary %q not found in archive", binName)
+}
+
+// replaceRunningBinary atomically swaps the currently running executable
+// for newBinary, keeping a <binary>.bak copy for `galaplate upgrade
+// --rollback`.
+func replaceRunningBinary(newBinary string) error {
+	current, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	current, err = filepath.EvalSymlinks(current)
+	if err != nil {
+		return err
+	}
+
+	backup := current + ".bak"
+	if err := copyFilePreservingMode(current, backup); err != nil {
+		return fmt.Errorf("failed to back up current binary: %v", err)
+	}
+
+	// Write the new binary to a sibling temp file, then rename it into
+	// place, so a crash mid-write never leaves a half-written executable.
+	tmp := current + ".new"
+	if err := copyFilePreservingMode(newBinary, tmp); err != nil {
+		return fmt.Errorf("failed to stage new binary: %v", err)
+	}
+	if err := os.Rename(tmp, current); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to replace binary: %v", err)
+	}
+
+	return nil
+}
+
+// rollbackUpgrade restores the <binary>.bak saved by the last upgrade.
+func rollbackUpgrade() error {
+	current, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	current, err = filepath.EvalSymlinks(current)
+	if err != nil {
+		return err
+	}
+
+	backup := current + ".bak"
+	if _, err := os.Stat(backup); err != nil {
+		return fmt.Errorf("no backup found at %s", backup)
+	}
+
+	tmp := current + ".new"
+	if err := copyFilePreservingMode(backup, tmp); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, current); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	fmt.Println("✅ Rolled back to the previous binary")
+	return nil
+}
+
+func copyFilePreservingMode(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode())
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestFindAsset(t *testing.T) {
+	release := &githubRelease{
+		Assets: []struct {
+			Name               string `json:"name"`
+			BrowserDownloadURL string `json:"browser_download_url"`
+		}{
+			{Name: "galaplate_darwin_arm64.tar.gz", BrowserDownloadURL: "https://example.com/darwin"},
+			{Name: "galaplate_" + runtime.GOOS + "_" + runtime.GOARCH + ".tar.gz", BrowserDownloadURL: "https://example.com/match"},
+			{Name: "checksums.txt", BrowserDownloadURL: "https://example.com/checksums"},
+		},
+	}
+
+	asset, err := findAsset(release)
+	if err != nil {
+		t.Fatalf("findAsset: %v", err)
+	}
+	if asset.BrowserDownloadURL != "https://example.com/match" {
+		t.Fatalf("findAsset picked %q, want the one matching %s/%s", asset.Name, runtime.GOOS, runtime.GOARCH)
+	}
+}
+
+func TestFindAssetNoMatch(t *testing.T) {
+	release := &githubRelease{
+		Assets: []struct {
+			Name               string `json:"name"`
+			BrowserDownloadURL string `json:"browser_download_url"`
+		}{
+			{Name: "galaplate_plan9_386.tar.gz", BrowserDownloadURL: "https://example.com/plan9"},
+		},
+	}
+
+	if _, err := findAsset(release); err == nil {
+		t.Fatal("expected an error when no asset matches the current platform")
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	dir := t.TempDir()
+	path := dir + "/archive.tar.gz"
+	if err := os.WriteFile(path, []byte("hello world"), 0644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	// sha256("hello world")
+	const want = "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+	if err := verifyChecksum(path, want); err != nil {
+		t.Fatalf("verifyChecksum with the correct sum: %v", err)
+	}
+
+	if err := verifyChecksum(path, "deadbeef"); err == nil {
+		t.Fatal("expected an error for a mismatched checksum")
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var availableFeatures = []string{"auth", "jobs", "websocket"}
+
+// runWizard interactively collects project options, mirroring the flags
+// accepted by `galaplate new`. It is used both when `new` is invoked with no
+// arguments and when `--interactive` is passed alongside a project name.
+func runWizard(opts *ProjectOptions) error {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Println("🧙 Galaplate interactive project wizard")
+	fmt.Println()
+
+	if opts.Name == "" {
+		opts.Name = promptString(reader, "Project name", "")
+		if opts.Name == "" {
+			return fmt.Errorf("project name is required")
+		}
+	}
+
+	if opts.Module == opts.Name || opts.Module == "" {
+		opts.Module = promptString(reader, "Module path", opts.Name)
+	}
+
+	opts.Template = promptSelect(reader, "Template", []string{"api", "full", "micro"}, opts.Template)
+	opts.DbType = promptSelect(reader, "Database", []string{"postgres", "mysql"}, opts.DbType)
+
+	features := promptMultiSelect(reader, "Optional features (comma-separated, blank for none)", availableFeatures)
+	opts.Features = strings.Join(features, ",")
+
+	opts.NoGit = !promptYesNo(reader, "Initialize a git repository?", !opts.NoGit)
+
+	fmt.Println()
+	fmt.Println("Summary:")
+	fmt.Printf("  name:     %s\n", opts.Name)
+	fmt.Printf("  module:   %s\n", opts.Module)
+	fmt.Printf("  template: %s\n", opts.Template)
+	fmt.Printf("  db:       %s\n", opts.DbType)
+	fmt.Printf("  features: %s\n", strings.Join(features, ", "))
+	fmt.Printf("  git:      %v\n", !opts.NoGit)
+	fmt.Println()
+
+	if !promptYesNo(reader, "Generate the project with these settings?", true) {
+		return fmt.Errorf("cancelled")
+	}
+
+	if opts.SaveProfile != "" {
+		profile := &Profile{
+			Template: opts.Template,
+			DbType:   opts.DbType,
+			Module:   opts.Module,
+			Features: features,
+			NoGit:    opts.NoGit,
+		}
+		if err := SaveProfile(opts.SaveProfile, profile); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: failed to save profile %q: %v\n", opts.SaveProfile, err)
+		} else {
+			fmt.Printf("💾 Saved answers as profile %q\n", opts.SaveProfile)
+		}
+	}
+
+	return nil
+}
+
+func promptString(reader *bufio.Reader, label, def string) string {
+	if def != "" {
+		fmt.Printf("%s [%s]: ", label, def)
+	} else {
+		fmt.Printf("%s: ", label)
+	}
+	line, _ := reader.ReadString('\n')
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return def
+	}
+	return line
+}
+
+func promptSelect(reader *bufio.Reader, label string, choices []string, def string) string {
+	fmt.Printf("%s (%s) [%s]: ", label, strings.Join(choices, "/"), def)
+	line, _ := reader.ReadString('\n')
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return def
+	}
+	for _, c := range choices {
+		if c == line {
+			return c
+		}
+	}
+	fmt.Printf("  ⚠️  %q is not one of %s, using %q\n", line, strings.Join(choices, "/"), def)
+	return def
+}
+
+func promptMultiSelect(reader *bufio.Reader, label string, choices []string) []string {
+	fmt.Printf("%s (%s): ", label, strings.Join(choices, ", "))
+	line, _ := reader.ReadString('\n')
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil
+	}
+
+	var selected []string
+	for _, part := range strings.Split(line, ",") {
+		part = strings.TrimSpace(part)
+		for _, c := range choices {
+			if c == part {
+				selected = append(selected, c)
+				break
+			}
+		}
+	}
+	return selected
+}
+
+func promptYesNo(reader *bufio.Reader, label string, def bool) bool {
+	hint := "Y/n"
+	if !def {
+		hint = "y/N"
+	}
+	fmt.Printf("%s [%s]: ", label, hint)
+	line, _ := reader.ReadString('\n')
+	line = strings.ToLower(strings.TrimSpace(line))
+	switch line {
+	case "":
+		return def
+	case "y", "yes":
+		return true
+	case "n", "no":
+		return false
+	default:
+		return def
+	}
+}